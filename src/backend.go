@@ -0,0 +1,25 @@
+package main
+
+// Backend is the subset of *store.Store that cmd/kv actually calls. Every
+// command function takes a Backend instead of a concrete *store.Store so a
+// second implementation can stand in for it.
+//
+// store.Store itself (github.com/asggo/store) is hard-wired to bbolt and
+// lives in a module this repo doesn't own, so it can't grow a real
+// registry of swappable drivers (bitcask, pogreb, badger, ...) from here --
+// that would have to happen upstream. What this interface buys us locally
+// is a second, dependency-free Backend (memBackend, in membackend.go) for
+// quick scripting against ":memory:" without touching a bbolt file.
+type Backend interface {
+	CreateBucket(bucket string) error
+	DeleteBucket(bucket string) error
+	AllBuckets() ([]string, error)
+	AllKeys(bucket string) ([]string, error)
+	FindBuckets(needle string) ([]string, error)
+	FindKeys(bucket, needle string) ([]string, error)
+	Read(bucket, key string) []byte
+	Write(bucket, key string, value []byte) error
+	Delete(bucket, key string) error
+	Backup(filename string) error
+	Close() error
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+// captureOutput runs fn with os.Stdout redirected to an in-memory buffer and
+// returns whatever it wrote. The server uses this to ship a command's
+// regular stdout output back over the network instead of printing it
+// locally.
+func captureOutput(fn func()) []byte {
+	old := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		// Nothing sane to do without a pipe; run uncaptured rather than
+		// drop the command entirely.
+		fn()
+		return nil
+	}
+
+	os.Stdout = w
+
+	done := make(chan []byte, 1)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		done <- buf.Bytes()
+	}()
+
+	fn()
+
+	w.Close()
+	os.Stdout = old
+
+	return <-done
+}
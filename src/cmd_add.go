@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const addUsage = `	add <bucketname>                 Add a new bucket to the database.
+	                                 Bucketname may be "/"-separated
+	                                 (e.g. "users/alice") to group related
+	                                 buckets; see the tree command.
+	add <bucketname> <key> <value>   Add the key/value to the bucket.
+	add <bucketname> <key> <value> --ttl <duration>
+	                                 Add the key/value to the bucket, expiring
+	                                 it after duration (e.g. "5m", "24h").`
+
+// add <bucketname>                               Adds a new bucket to the database.
+// add <bucketname> <key> <value>                 Add the key/value to the bucket.
+// add <bucketname> <key> <value> --ttl <duration> Add the key/value, expiring it after duration.
+func add(db Backend, args []string) {
+	if len(args) == 5 && args[3] == "--ttl" {
+		ttl, err := time.ParseDuration(args[4])
+		if err != nil {
+			fmt.Printf("Invalid --ttl value %q: %s\n", args[4], err)
+			return
+		}
+
+		if err := writeWithTTL(db, args[0], args[1], []byte(args[2]), ttl); err != nil {
+			fmt.Printf("Could not write to bucket %s: %s\n", args[0], err)
+		}
+		return
+	}
+
+	switch len(args) {
+	case 1:
+		err := db.CreateBucket(args[0])
+		if err != nil {
+			fmt.Printf("Could not create bucket %s: %s\n", args[0], err)
+		}
+	case 3:
+		err := db.Write(args[0], args[1], []byte(args[2]))
+		if err != nil {
+			fmt.Printf("Could not write to bucket %s: %s\n", args[0], err)
+		}
+		// A plain write replaces the value outright, so any expiry recorded
+		// against the old value must go with it -- otherwise the new value
+		// silently inherits the old, possibly already-past, TTL.
+		db.Delete(metaBucket(args[0]), args[1])
+	default:
+		usage(addUsage)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string // substring expected in db.Read after running, "" if no write expected
+	}{
+		{"create bucket", []string{"fruit"}, ""},
+		{"write key", []string{"fruit", "apple", "red"}, "red"},
+		{"bad arg count", []string{"fruit", "apple"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB()
+			db.CreateBucket("fruit")
+
+			add(db, tt.args)
+
+			if tt.want != "" {
+				got := string(db.Read("fruit", "apple"))
+				if got != tt.want {
+					t.Errorf("db.Read(fruit, apple) = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAddWithTTLExpires(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+
+	add(db, []string{"fruit", "apple", "red", "--ttl", "1ms"})
+	time.Sleep(5 * time.Millisecond)
+
+	value, expired := readWithMeta(db, "fruit", "apple")
+	if !expired || value != nil {
+		t.Errorf("readWithMeta after ttl elapsed = (%q, %v), want (nil, true)", value, expired)
+	}
+}
+
+func TestAddPlainOverwriteClearsExpiry(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+
+	add(db, []string{"fruit", "apple", "red", "--ttl", "1h"})
+	add(db, []string{"fruit", "apple", "green"})
+
+	if expiredAt(db, "fruit", "apple") {
+		t.Error("expiredAt() = true after plain overwrite, want false: TTL should have been cleared")
+	}
+	if got := string(db.Read("fruit", "apple")); got != "green" {
+		t.Errorf("db.Read(fruit, apple) = %q, want %q", got, "green")
+	}
+}
+
+func TestAddBadTTLReportsError(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+
+	out := run(t, add, db, "fruit", "apple", "red", "--ttl", "not-a-duration")
+
+	if !strings.Contains(out, "Invalid --ttl value") {
+		t.Errorf("output = %q, want mention of invalid --ttl value", out)
+	}
+	if db.Read("fruit", "apple") != nil {
+		t.Error("value was written despite an invalid --ttl")
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+)
+
+const backupUsage = `	backup <filename>                Backup the database to this file.`
+
+func backup(db Backend, args []string) {
+	switch len(args) {
+	case 1:
+		err := db.Backup(args[0])
+		if err != nil {
+			fmt.Printf("Could not backup database to %s: %s\n", args[0], err)
+			return
+		}
+	default:
+		usage(backupUsage)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const batchUsage = `	batch <scriptfile>                Run a script of
+	                                 "set bucket key value" / "del bucket key"
+	                                 / "mkbucket name" lines against the
+	                                 database, one line at a time. NOT
+	                                 atomic: each line is its own
+	                                 transaction, so a script that fails
+	                                 partway through leaves earlier lines
+	                                 applied and later lines un-run.`
+
+// batch <scriptfile>
+//
+// Runs a small line-oriented script against the database. This is NOT the
+// atomic multi-op transaction a real batch command would want: store.Store
+// (github.com/asggo/store) only hands out one bbolt transaction per call and
+// doesn't expose anything lower-level, and it lives in a module this repo
+// doesn't own, so there is no way to wrap a whole script in a single
+// Update(). Each line still runs as its own transaction against the same
+// open *store.Store, which is faster than re-invoking the CLI per line but
+// gives no atomicity: a script that fails partway through leaves earlier
+// lines applied.
+func batch(db Backend, args []string) {
+	if len(args) != 1 {
+		usage(batchUsage)
+		return
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		fmt.Printf("Could not open %s: %s\n", args[0], err)
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if err := runBatchLine(db, line); err != nil {
+			fmt.Printf("line %d: %s\n", n, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		fmt.Printf("Could not read %s: %s\n", args[0], err)
+	}
+}
+
+func runBatchLine(db Backend, line string) error {
+	fields := strings.SplitN(line, " ", 4)
+
+	switch fields[0] {
+	case "mkbucket":
+		if len(fields) != 2 {
+			return fmt.Errorf("usage: mkbucket name")
+		}
+		return db.CreateBucket(fields[1])
+	case "set":
+		if len(fields) != 4 {
+			return fmt.Errorf("usage: set bucket key value")
+		}
+		return db.Write(fields[1], fields[2], []byte(fields[3]))
+	case "del":
+		if len(fields) != 3 {
+			return fmt.Errorf("usage: del bucket key")
+		}
+		return db.Delete(fields[1], fields[2])
+	default:
+		return fmt.Errorf("unknown batch command %q", fields[0])
+	}
+}
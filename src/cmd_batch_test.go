@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func writeScript(t *testing.T, contents string) string {
+	t.Helper()
+
+	f, err := os.CreateTemp(t.TempDir(), "batch-*.txt")
+	if err != nil {
+		t.Fatalf("CreateTemp: %s", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(contents); err != nil {
+		t.Fatalf("WriteString: %s", err)
+	}
+
+	return f.Name()
+}
+
+func TestBatch(t *testing.T) {
+	db := newTestDB()
+	script := writeScript(t, "mkbucket fruit\nset fruit apple red\n# a comment\ndel fruit apple\n")
+
+	batch(db, []string{script})
+
+	if db.Read("fruit", "apple") != nil {
+		t.Error("apple still readable after del line ran")
+	}
+}
+
+func TestBatchReportsBadLineButKeepsGoing(t *testing.T) {
+	db := newTestDB()
+	script := writeScript(t, "mkbucket fruit\nnonsense line\nset fruit apple red\n")
+
+	out := run(t, batch, db, script)
+
+	if !strings.Contains(out, "line 2") {
+		t.Errorf("batch output = %q, want it to report the bad line number", out)
+	}
+	if got := string(db.Read("fruit", "apple")); got != "red" {
+		t.Errorf("db.Read(fruit, apple) = %q, want %q: a bad line must not stop later lines from running", got, "red")
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+)
+
+const clientUsage = `	<addr> client <action> [arguments]  Send one command to a running
+	                                 "kv <file> serve --listen <addr>"
+	                                 instance instead of opening a file.`
+
+// client dials addr (tcp or unix, per dialNetwork) and sends args as one
+// framed command, then prints whatever the server captured from the
+// command's normal stdout output.
+func client(addr string, args []string) {
+	if len(args) == 0 {
+		fmt.Println(clientUsage)
+		os.Exit(1)
+	}
+
+	conn, err := net.Dial(dialNetwork(addr), addr)
+	if err != nil {
+		fmt.Printf("Could not connect to %s: %s\n", addr, err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	w := bufio.NewWriter(conn)
+	if err := writeCommand(w, args); err != nil {
+		fmt.Printf("Could not send command: %s\n", err)
+		os.Exit(1)
+	}
+
+	reply, err := readReply(bufio.NewReader(conn))
+	if err != nil {
+		fmt.Printf("Could not read reply: %s\n", err)
+		os.Exit(1)
+	}
+
+	os.Stdout.Write(reply)
+}
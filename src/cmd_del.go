@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+)
+
+const delUsage = `	del <bucketname>                 Delete the bucket and its keys.
+	del <bucketname> <key>           Delete the key/value in the bucket`
+
+// del <bucketname>         Delete the bucket and its keys.
+// del <bucketname> <key>   Delete the key/value in the bucket
+func del(db Backend, args []string) {
+	switch len(args) {
+	case 1:
+		err := db.DeleteBucket(args[0])
+		if err != nil {
+			fmt.Printf("Could not delete bucket %s: %s\n", args[0], err)
+		}
+		// Drop the bucket's TTL bookkeeping too, or recreating the bucket
+		// later lets a key inherit a stale, already-passed expiry.
+		db.DeleteBucket(metaBucket(args[0]))
+	case 2:
+		err := db.Delete(args[0], args[1])
+		if err != nil {
+			fmt.Printf("Could not delete key %s from bucket %s: %s\n", args[0], args[1], err)
+		}
+		db.Delete(metaBucket(args[0]), args[1])
+	default:
+		usage(delUsage)
+	}
+}
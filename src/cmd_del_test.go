@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDelKey(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	db.Write("fruit", "apple", []byte("red"))
+
+	del(db, []string{"fruit", "apple"})
+
+	if db.Read("fruit", "apple") != nil {
+		t.Error("key still readable after del")
+	}
+}
+
+func TestDelKeyClearsExpiry(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	writeWithTTL(db, "fruit", "apple", []byte("red"), time.Hour)
+
+	del(db, []string{"fruit", "apple"})
+	db.Write("fruit", "apple", []byte("green"))
+
+	if expiredAt(db, "fruit", "apple") {
+		t.Error("expiredAt() = true for a key re-written after del, want false: stale TTL meta should have been cleared")
+	}
+}
+
+func TestDelBucketClearsMetaBucket(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	writeWithTTL(db, "fruit", "apple", []byte("red"), time.Hour)
+
+	del(db, []string{"fruit"})
+
+	buckets, _ := db.AllBuckets()
+	for _, b := range buckets {
+		if b == metaBucket("fruit") {
+			t.Errorf("AllBuckets() = %v, still contains meta bucket after deleting fruit", buckets)
+		}
+	}
+}
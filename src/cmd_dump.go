@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+)
+
+const dumpUsage = `	dump                             Print every bucket/key/value in the
+	                                 database in a human-readable form.`
+
+// dump prints every bucket, key and value in the database. It is the quick
+// "what's actually in here" check; export is the machine-readable form of
+// the same walk.
+func dump(db Backend, args []string) {
+	if len(args) != 0 {
+		usage(dumpUsage)
+	}
+
+	buckets, err := db.AllBuckets()
+	if err != nil {
+		fmt.Printf("Could not retrieve bucket list: %s\n", err)
+		return
+	}
+
+	for _, bucket := range withoutMetaBuckets(buckets) {
+		keys, err := db.AllKeys(bucket)
+		if err != nil {
+			fmt.Printf("Could not retrieve keys from bucket %s: %s\n", bucket, err)
+			continue
+		}
+
+		for _, key := range keys {
+			fmt.Printf("%s\t%s\t%s\n", bucket, key, db.Read(bucket, key))
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	db.Write("fruit", "apple", []byte("red"))
+
+	out := run(t, dump, db)
+
+	if !strings.Contains(out, "fruit\tapple\tred") {
+		t.Errorf("dump() = %q, want a fruit\\tapple\\tred line", out)
+	}
+}
+
+func TestDumpHidesMetaBuckets(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	writeWithTTL(db, "fruit", "apple", []byte("red"), -1)
+
+	out := run(t, dump, db)
+
+	if strings.Contains(out, "__meta") {
+		t.Errorf("dump() = %q, should not print TTL __meta buckets", out)
+	}
+}
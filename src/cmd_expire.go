@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const expireUsage = `	expire <bucketname> <key> <duration>  Set or replace a key's TTL without
+	                                 touching its value (e.g. "5m", "24h").
+	expire --sweep [bucketname]      Delete every key whose TTL has passed,
+	                                 in one bucket or, if omitted, the whole
+	                                 database.`
+
+// expire <bucketname> <key> <duration>   Set or replace a key's TTL.
+// expire --sweep [bucketname]            Reap expired keys now, instead of
+//
+//	waiting for a read or the
+//	background sweeper in serve.
+func expire(db Backend, args []string) {
+	if len(args) >= 1 && args[0] == "--sweep" {
+		sweep(db, args[1:])
+		return
+	}
+
+	switch len(args) {
+	case 3:
+		ttl, err := time.ParseDuration(args[2])
+		if err != nil {
+			fmt.Printf("Invalid duration %q: %s\n", args[2], err)
+			return
+		}
+
+		if err := setExpiry(db, args[0], args[1], time.Now().Add(ttl)); err != nil {
+			fmt.Printf("Could not set expiry for %s/%s: %s\n", args[0], args[1], err)
+		}
+	default:
+		usage(expireUsage)
+	}
+}
+
+func sweep(db Backend, args []string) {
+	switch len(args) {
+	case 0:
+		n, err := reapAll(db)
+		if err != nil {
+			fmt.Printf("Could not sweep database: %s\n", err)
+			return
+		}
+		fmt.Printf("Removed %d expired key(s)\n", n)
+	case 1:
+		n, err := reap(db, args[0])
+		if err != nil {
+			fmt.Printf("Could not sweep bucket %s: %s\n", args[0], err)
+			return
+		}
+		fmt.Printf("Removed %d expired key(s)\n", n)
+	default:
+		usage(expireUsage)
+	}
+}
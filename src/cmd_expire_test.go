@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpireSetsExpiry(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	db.Write("fruit", "apple", []byte("red"))
+
+	expire(db, []string{"fruit", "apple", "-1ns"})
+
+	if !expiredAt(db, "fruit", "apple") {
+		t.Error("expiredAt() = false after expire set a past duration, want true")
+	}
+}
+
+func TestExpireSweep(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	writeWithTTL(db, "fruit", "apple", []byte("red"), -1)
+
+	out := run(t, expire, db, "--sweep")
+
+	if !strings.Contains(out, "Removed 1 expired key") {
+		t.Errorf("expire --sweep output = %q, want it to report removing the expired key", out)
+	}
+	if keys, _ := db.AllKeys("fruit"); len(keys) != 0 {
+		t.Errorf("AllKeys(fruit) = %v after sweep, want none", keys)
+	}
+}
+
+func TestExpireSweepSingleBucket(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	db.CreateBucket("veg")
+	writeWithTTL(db, "fruit", "apple", []byte("red"), -1)
+	writeWithTTL(db, "veg", "carrot", []byte("orange"), -1)
+
+	expire(db, []string{"--sweep", "fruit"})
+
+	if keys, _ := db.AllKeys("veg"); len(keys) != 1 {
+		t.Errorf("AllKeys(veg) = %v, sweeping fruit should not touch veg", keys)
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+const exportUsage = `	export [bucketname] --format json|ndjson|csv   Export the database, or a
+	                                 single bucket, to stdout. Format
+	                                 defaults to json.`
+
+// exportRecord is one bucket/key/value triple, the common unit every export
+// format is built from.
+type exportRecord struct {
+	Bucket string
+	Key    string
+	Value  []byte
+}
+
+// export [bucketname] --format json|ndjson|csv
+//
+// Streams every bucket (or just bucketname, if given) to stdout. Values are
+// arbitrary bytes, so every format carries them base64 encoded.
+func export(db Backend, args []string) {
+	bucket, format, err := parseExportArgs(args)
+	if err != nil {
+		fmt.Println(err)
+		usage(exportUsage)
+		return
+	}
+
+	records, err := collectRecords(db, bucket)
+	if err != nil {
+		fmt.Printf("Could not export database: %s\n", err)
+		return
+	}
+
+	switch format {
+	case "json":
+		err = exportJSON(os.Stdout, records)
+	case "ndjson":
+		err = exportNDJSON(os.Stdout, records)
+	case "csv":
+		err = exportCSV(os.Stdout, records)
+	default:
+		err = fmt.Errorf("unknown format %q", format)
+	}
+
+	if err != nil {
+		fmt.Printf("Could not export database: %s\n", err)
+	}
+}
+
+// parseExportArgs pulls the optional bucket name and --format flag out of
+// args. args may be `[bucketname] [--format fmt]` in either order.
+func parseExportArgs(args []string) (bucket, format string, err error) {
+	format = "json"
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--format":
+			if i+1 >= len(args) {
+				return "", "", fmt.Errorf("--format requires a value")
+			}
+			format = args[i+1]
+			i++
+		default:
+			if bucket != "" {
+				return "", "", fmt.Errorf("unexpected argument %q", args[i])
+			}
+			bucket = args[i]
+		}
+	}
+
+	switch format {
+	case "json", "ndjson", "csv":
+	default:
+		return "", "", fmt.Errorf("unsupported format %q", format)
+	}
+
+	return bucket, format, nil
+}
+
+// collectRecords reads every key/value pair from bucket, or from every
+// bucket in the database if bucket is empty.
+func collectRecords(db Backend, bucket string) ([]exportRecord, error) {
+	buckets := []string{bucket}
+	if bucket == "" {
+		var err error
+		buckets, err = db.AllBuckets()
+		if err != nil {
+			return nil, err
+		}
+		// TTL bookkeeping buckets aren't real data -- exporting/backing them
+		// up would re-materialize them (and their possibly-stale expiries)
+		// on import.
+		buckets = withoutMetaBuckets(buckets)
+	}
+
+	var records []exportRecord
+	for _, b := range buckets {
+		keys, err := db.AllKeys(b)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			records = append(records, exportRecord{
+				Bucket: b,
+				Key:    key,
+				Value:  db.Read(b, key),
+			})
+		}
+	}
+
+	return records, nil
+}
+
+// exportJSON writes one object per bucket with a nested key -> base64 value
+// map, e.g. {"bucketname": {"key": "dmFsdWU="}}.
+func exportJSON(w *os.File, records []exportRecord) error {
+	out := map[string]map[string]string{}
+
+	for _, r := range records {
+		if out[r.Bucket] == nil {
+			out[r.Bucket] = map[string]string{}
+		}
+		out[r.Bucket][r.Key] = base64.StdEncoding.EncodeToString(r.Value)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// exportNDJSON writes one {"bucket":...,"key":...,"value_b64":...} object
+// per line.
+func exportNDJSON(w *os.File, records []exportRecord) error {
+	enc := json.NewEncoder(w)
+
+	for _, r := range records {
+		line := struct {
+			Bucket   string `json:"bucket"`
+			Key      string `json:"key"`
+			ValueB64 string `json:"value_b64"`
+		}{r.Bucket, r.Key, base64.StdEncoding.EncodeToString(r.Value)}
+
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exportCSV writes a bucket,key,value_b64 header followed by one row per
+// record.
+func exportCSV(w *os.File, records []exportRecord) error {
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	if err := cw.Write([]string{"bucket", "key", "value_b64"}); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		row := []string{r.Bucket, r.Key, base64.StdEncoding.EncodeToString(r.Value)}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportFormats(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	db.Write("fruit", "apple", []byte("red"))
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"json", `"apple"`},
+		{"ndjson", `"key":"apple"`},
+		{"csv", "apple,"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			out := run(t, export, db, "--format", tt.format)
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("export --format %s = %q, want substring %q", tt.format, out, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportHidesMetaBuckets(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	writeWithTTL(db, "fruit", "apple", []byte("red"), -1)
+
+	out := run(t, export, db, "--format", "ndjson")
+
+	if strings.Contains(out, "__meta") {
+		t.Errorf("export = %q, should not export TTL __meta buckets", out)
+	}
+}
+
+func TestExportUnsupportedFormat(t *testing.T) {
+	db := newTestDB()
+
+	out := run(t, export, db, "--format", "xml")
+
+	if !strings.Contains(out, "unsupported format") {
+		t.Errorf("export --format xml = %q, want an unsupported-format error", out)
+	}
+}
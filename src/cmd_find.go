@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+)
+
+const findUsage = `	find <string>                    Find buckets whose name contains the string.
+	find <bucketname> <string>       Find keys whose name contain the string.`
+
+// find <string>                Find all buckets in the database, which contain the string.
+// find <bucketname> <string>   Find all keys in the bucket, which contain the string.
+func find(db Backend, args []string) {
+	var items []string
+	var err error
+
+	switch len(args) {
+	case 1:
+		items, err = db.FindBuckets(args[0])
+		if err != nil {
+			fmt.Printf("Could not find buckets matching %s: %s\n", args[0], err)
+		}
+		items = withoutMetaBuckets(items)
+	case 2:
+		items, err = db.FindKeys(args[0], args[1])
+		if err != nil {
+			fmt.Printf("Could not find keys matching %s in bucket %s: %s\n", args[1], args[0], err)
+			return
+		}
+	default:
+		usage(findUsage)
+	}
+
+	printlist(items)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	db.CreateBucket("vegetable")
+	db.Write("fruit", "apple", []byte("red"))
+	db.Write("fruit", "banana", []byte("yellow"))
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"find buckets", []string{"fru"}, "fruit"},
+		{"find keys", []string{"fruit", "app"}, "apple"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := run(t, find, db, tt.args...)
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("find(%v) = %q, want substring %q", tt.args, out, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindHidesMetaBuckets(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	db.CreateBucket(metaBucket("fruit"))
+
+	out := run(t, find, db, "fruit")
+
+	if strings.Contains(out, "__meta") {
+		t.Errorf("find(fruit) = %q, should not match TTL __meta buckets", out)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+)
+
+const getUsage = `	get                              Get a list of buckets.
+	get <bucketname>                 Get a list of keys in a bucket.
+	get <bucketname> <key>           Get the value of the key in the bucket.`
+
+// get                      Returns a list of buckets.
+// get <bucketname>         Returns all keys in a bucket.
+// get <bucketname> <key>   Returns the value of the key in the bucket.
+func get(db Backend, args []string) {
+	var items []string
+	var err error
+
+	switch len(args) {
+	case 0:
+		items, err = db.AllBuckets()
+		if err != nil {
+			fmt.Printf("Could not retrieve bucket list: %s\n", err)
+		}
+		items = withoutMetaBuckets(items)
+	case 1:
+		items, err = db.AllKeys(args[0])
+		if err != nil {
+			fmt.Printf("Could not retrieve keys from bucket %s: %s\n", args[0], err)
+		}
+	case 2:
+		value, _ := readWithMeta(db, args[0], args[1])
+		fmt.Println(string(value))
+	default:
+		usage(getUsage)
+	}
+
+	printlist(items)
+}
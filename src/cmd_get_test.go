@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	db.Write("fruit", "apple", []byte("red"))
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"list buckets", nil, "fruit"},
+		{"list keys", []string{"fruit"}, "apple"},
+		{"get value", []string{"fruit", "apple"}, "red"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := run(t, get, db, tt.args...)
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("get(%v) = %q, want substring %q", tt.args, out, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetHidesMetaBuckets(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	db.CreateBucket(metaBucket("fruit"))
+
+	out := run(t, get, db)
+
+	if strings.Contains(out, "__meta") {
+		t.Errorf("get() = %q, should not list TTL __meta buckets", out)
+	}
+}
+
+func TestGetExpiredKeyReadsAsEmpty(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	writeWithTTL(db, "fruit", "apple", []byte("red"), -1)
+
+	out := run(t, get, db, "fruit", "apple")
+
+	if strings.TrimSpace(out) != "" {
+		t.Errorf("get(fruit, apple) on an expired key = %q, want empty", out)
+	}
+}
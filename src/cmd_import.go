@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+const importUsage = `	import <filename> --format json|ndjson|csv [--overwrite|--skip-existing]
+	                                 Import records produced by export.`
+
+// import <filename> --format json|ndjson|csv [--overwrite|--skip-existing]
+//
+// Reads records in the same shape export produces and writes them back with
+// CreateBucket/Write. By default an existing key is left alone and reported;
+// --overwrite replaces it, --skip-existing silently leaves it in place.
+func doImport(db Backend, args []string) {
+	path, format, onExisting, err := parseImportArgs(args)
+	if err != nil {
+		fmt.Println(err)
+		usage(importUsage)
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Printf("Could not open %s: %s\n", path, err)
+		return
+	}
+	defer f.Close()
+
+	var records []exportRecord
+	switch format {
+	case "json":
+		records, err = readImportJSON(f)
+	case "ndjson":
+		records, err = readImportNDJSON(f)
+	case "csv":
+		records, err = readImportCSV(f)
+	default:
+		err = fmt.Errorf("unsupported format %q", format)
+	}
+
+	if err != nil {
+		fmt.Printf("Could not read %s: %s\n", path, err)
+		return
+	}
+
+	writeRecords(db, records, onExisting)
+}
+
+// onExisting controls what happens when an imported key is already present.
+type onExisting int
+
+const (
+	failExisting onExisting = iota
+	overwriteExisting
+	skipExisting
+)
+
+func parseImportArgs(args []string) (path, format string, existing onExisting, err error) {
+	format = "json"
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--format":
+			if i+1 >= len(args) {
+				return "", "", 0, fmt.Errorf("--format requires a value")
+			}
+			format = args[i+1]
+			i++
+		case "--overwrite":
+			existing = overwriteExisting
+		case "--skip-existing":
+			existing = skipExisting
+		default:
+			if path != "" {
+				return "", "", 0, fmt.Errorf("unexpected argument %q", args[i])
+			}
+			path = args[i]
+		}
+	}
+
+	if path == "" {
+		return "", "", 0, fmt.Errorf("import requires a filename")
+	}
+
+	return path, format, existing, nil
+}
+
+func readImportJSON(r io.Reader) ([]exportRecord, error) {
+	var in map[string]map[string]string
+
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return nil, err
+	}
+
+	var records []exportRecord
+	for bucket, keys := range in {
+		for key, valueB64 := range keys {
+			value, err := base64.StdEncoding.DecodeString(valueB64)
+			if err != nil {
+				return nil, fmt.Errorf("bad value for %s/%s: %s", bucket, key, err)
+			}
+			records = append(records, exportRecord{Bucket: bucket, Key: key, Value: value})
+		}
+	}
+
+	return records, nil
+}
+
+func readImportNDJSON(r io.Reader) ([]exportRecord, error) {
+	var records []exportRecord
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		var row struct {
+			Bucket   string `json:"bucket"`
+			Key      string `json:"key"`
+			ValueB64 string `json:"value_b64"`
+		}
+
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			return nil, err
+		}
+
+		value, err := base64.StdEncoding.DecodeString(row.ValueB64)
+		if err != nil {
+			return nil, fmt.Errorf("bad value for %s/%s: %s", row.Bucket, row.Key, err)
+		}
+
+		records = append(records, exportRecord{Bucket: row.Bucket, Key: row.Key, Value: value})
+	}
+
+	return records, scanner.Err()
+}
+
+func readImportCSV(r io.Reader) ([]exportRecord, error) {
+	cr := csv.NewReader(r)
+
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	var records []exportRecord
+	for _, row := range rows[1:] { // skip the bucket,key,value_b64 header
+		if len(row) != 3 {
+			return nil, fmt.Errorf("expected 3 columns, got %d", len(row))
+		}
+
+		value, err := base64.StdEncoding.DecodeString(row[2])
+		if err != nil {
+			return nil, fmt.Errorf("bad value for %s/%s: %s", row[0], row[1], err)
+		}
+
+		records = append(records, exportRecord{Bucket: row[0], Key: row[1], Value: value})
+	}
+
+	return records, nil
+}
+
+// writeRecords replays records into db, creating buckets as needed.
+func writeRecords(db Backend, records []exportRecord, existing onExisting) {
+	created := map[string]bool{}
+	existingKeys := map[string]map[string]bool{}
+
+	for _, r := range records {
+		if !created[r.Bucket] {
+			if err := db.CreateBucket(r.Bucket); err != nil {
+				fmt.Printf("Could not create bucket %s: %s\n", r.Bucket, err)
+				continue
+			}
+			created[r.Bucket] = true
+		}
+
+		if existingKeys[r.Bucket] == nil {
+			existingKeys[r.Bucket] = map[string]bool{}
+			keys, _ := db.AllKeys(r.Bucket)
+			for _, key := range keys {
+				existingKeys[r.Bucket][key] = true
+			}
+		}
+
+		if existing != overwriteExisting && existingKeys[r.Bucket][r.Key] {
+			if existing == skipExisting {
+				continue
+			}
+			fmt.Printf("Key %s already exists in bucket %s, skipping (use --overwrite or --skip-existing)\n", r.Key, r.Bucket)
+			continue
+		}
+
+		if err := db.Write(r.Bucket, r.Key, r.Value); err != nil {
+			fmt.Printf("Could not write %s/%s: %s\n", r.Bucket, r.Key, err)
+		}
+		existingKeys[r.Bucket][r.Key] = true
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestImportRoundTripsExport(t *testing.T) {
+	src := newTestDB()
+	src.CreateBucket("fruit")
+	src.Write("fruit", "apple", []byte("red"))
+
+	path := writeScript(t, "")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Create: %s", err)
+	}
+	captureTo(t, f, func() { export(src, []string{"--format", "json"}) })
+	f.Close()
+
+	dst := newTestDB()
+	doImport(dst, []string{path, "--format", "json"})
+
+	if got := string(dst.Read("fruit", "apple")); got != "red" {
+		t.Errorf("db.Read(fruit, apple) after import = %q, want %q", got, "red")
+	}
+}
+
+// captureTo redirects os.Stdout to f for the duration of fn, the same way
+// export normally writes straight to os.Stdout.
+func captureTo(t *testing.T, f *os.File, fn func()) {
+	t.Helper()
+	old := os.Stdout
+	os.Stdout = f
+	defer func() { os.Stdout = old }()
+	fn()
+}
+
+func TestImportSkipExisting(t *testing.T) {
+	path := writeScript(t, `{"fruit":{"apple":"cmVk"}}`)
+
+	dst := newTestDB()
+	dst.CreateBucket("fruit")
+	dst.Write("fruit", "apple", []byte("green"))
+
+	doImport(dst, []string{path, "--format", "json", "--skip-existing"})
+
+	if got := string(dst.Read("fruit", "apple")); got != "green" {
+		t.Errorf("db.Read(fruit, apple) = %q, want %q: --skip-existing must not overwrite", got, "green")
+	}
+}
+
+func TestImportOverwrite(t *testing.T) {
+	path := writeScript(t, `{"fruit":{"apple":"cmVk"}}`)
+
+	dst := newTestDB()
+	dst.CreateBucket("fruit")
+	dst.Write("fruit", "apple", []byte("green"))
+
+	doImport(dst, []string{path, "--format", "json", "--overwrite"})
+
+	if got := string(dst.Read("fruit", "apple")); got != "red" {
+		t.Errorf("db.Read(fruit, apple) = %q, want %q: --overwrite should replace the existing value", got, "red")
+	}
+}
+
+func TestImportDefaultReportsExistingWithoutOverwriting(t *testing.T) {
+	path := writeScript(t, `{"fruit":{"apple":"cmVk"}}`)
+
+	dst := newTestDB()
+	dst.CreateBucket("fruit")
+	dst.Write("fruit", "apple", []byte("green"))
+
+	out := run(t, doImport, dst, path, "--format", "json")
+
+	if !strings.Contains(out, "already exists") {
+		t.Errorf("import output = %q, want an already-exists notice", out)
+	}
+	if got := string(dst.Read("fruit", "apple")); got != "green" {
+		t.Errorf("db.Read(fruit, apple) = %q, want %q: default import must not overwrite", got, "green")
+	}
+}
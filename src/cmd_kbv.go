@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const kbvUsage = `	kbv <bucketname> <string>        Get a list of keys where the value
+	                                 contains the string.`
+
+// kbv <bucketname> <string>   Return all keys in the bucket whose value contains the string.
+func kbv(db Backend, args []string) {
+	switch len(args) {
+	case 2:
+		keys, err := db.AllKeys(args[0])
+		if err != nil {
+			fmt.Printf("Could not find keys for values matching %s in bucket %s: %s\n", args[1], args[0], err)
+			return
+		}
+
+		for _, key := range keys {
+			val, expired := readWithMeta(db, args[0], key)
+			if !expired && bytes.Contains(val, []byte(args[1])) {
+				fmt.Println(key)
+			}
+		}
+	default:
+		usage(kbvUsage)
+	}
+}
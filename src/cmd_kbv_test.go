@@ -0,0 +1,34 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKbv(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	db.Write("fruit", "apple", []byte("red"))
+	db.Write("fruit", "banana", []byte("yellow"))
+
+	out := run(t, kbv, db, "fruit", "red")
+
+	if !strings.Contains(out, "apple") {
+		t.Errorf("kbv(fruit, red) = %q, want substring %q", out, "apple")
+	}
+	if strings.Contains(out, "banana") {
+		t.Errorf("kbv(fruit, red) = %q, should not include banana", out)
+	}
+}
+
+func TestKbvSkipsExpired(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	writeWithTTL(db, "fruit", "apple", []byte("red"), -1)
+
+	out := run(t, kbv, db, "fruit", "red")
+
+	if strings.Contains(out, "apple") {
+		t.Errorf("kbv(fruit, red) = %q, should skip an expired key", out)
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often serve reaps expired TTL'd keys in the
+// background, so they don't just pile up waiting to be read.
+const sweepInterval = 30 * time.Second
+
+const serveUsage = `	serve [--listen addr]            Keep the database open. Serves the
+	                                 usual verbs on an interactive REPL
+	                                 read from stdin, and additionally
+	                                 over a TCP/unix socket if --listen
+	                                 is given (connect with
+	                                 "kv addr client ...").`
+
+// serveMu serializes command execution across the REPL and every network
+// connection, since they all share one *store.Store and captureOutput
+// redirects the single process-wide os.Stdout.
+var serveMu sync.Mutex
+
+// serve [--listen addr]
+//
+// Keeps db open for the life of the process instead of paying open/close
+// cost per invocation. Always runs a line-oriented REPL on stdin; if
+// --listen is given it also accepts pipelined requests over a TCP or unix
+// socket using the protocol in protocol.go.
+func serve(db Backend, args []string) {
+	addr, err := parseServeArgs(args)
+	if err != nil {
+		fmt.Println(err)
+		usage(serveUsage)
+		return
+	}
+
+	if addr != "" {
+		ln, err := net.Listen(dialNetwork(addr), addr)
+		if err != nil {
+			fmt.Printf("Could not listen on %s: %s\n", addr, err)
+			return
+		}
+		defer ln.Close()
+
+		go acceptLoop(db, ln)
+		fmt.Printf("Listening on %s\n", addr)
+	}
+
+	go sweepLoop(db)
+	repl(db)
+}
+
+func parseServeArgs(args []string) (addr string, err error) {
+	for i := 0; i < len(args); i++ {
+		if args[i] != "--listen" {
+			return "", fmt.Errorf("unexpected argument %q", args[i])
+		}
+		if i+1 >= len(args) {
+			return "", fmt.Errorf("--listen requires a value")
+		}
+		addr = args[i+1]
+		i++
+	}
+	return addr, nil
+}
+
+// repl reads whitespace separated commands from stdin, one per line, and
+// runs them the same way the CLI dispatch in main() does.
+func repl(db Backend) {
+	scanner := bufio.NewScanner(os.Stdin)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		if fields[0] == "quit" || fields[0] == "exit" {
+			return
+		}
+
+		serveMu.Lock()
+		runCommand(db, fields[0], fields[1:])
+		serveMu.Unlock()
+	}
+}
+
+// sweepLoop periodically reaps expired TTL'd keys for as long as serve is
+// running.
+func sweepLoop(db Backend) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		serveMu.Lock()
+		reapAll(db)
+		serveMu.Unlock()
+	}
+}
+
+// acceptLoop accepts connections on ln until it is closed, handling each one
+// in its own goroutine.
+func acceptLoop(db Backend, ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		go handleConn(db, conn)
+	}
+}
+
+// handleConn serves pipelined framed commands off one connection until it
+// errors or the client closes it.
+func handleConn(db Backend, conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+
+		out := dispatchSafely(db, args)
+
+		if err := writeReply(w, out); err != nil {
+			return
+		}
+	}
+}
+
+// dispatchSafely runs one command under serveMu and recovers from any
+// panic it raises, turning it into an error reply instead of letting it
+// cross the goroutine boundary and kill the whole server -- acceptLoop
+// spawns handleConn with no recover of its own, so a single connection's
+// bad input (or bug in a command) would otherwise take every other
+// connection down with it.
+func dispatchSafely(db Backend, args []string) (out []byte) {
+	serveMu.Lock()
+	defer serveMu.Unlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			out = []byte(fmt.Sprintf("internal error: %v\n", r))
+		}
+	}()
+
+	out = captureOutput(func() { runCommand(db, args[0], args[1:]) })
+	return out
+}
+
+// runCommand looks up action in the registry and runs it, printing a usage
+// error instead of exiting when it isn't found or is malformed -- unlike the
+// one-shot CLI, a bad command here must not take down the whole server.
+func runCommand(db Backend, action string, args []string) {
+	// serve is how the REPL and handleConn themselves got invoked; running
+	// it again here would call repl() a second time against the one shared
+	// os.Stdin while still holding serveMu, wedging every other command on
+	// this server, and would let a network client bind additional listeners
+	// of its choosing. main() still dispatches serve directly (it doesn't
+	// go through runCommand), so `kv file serve` from the shell keeps working.
+	if action == "serve" {
+		fmt.Println("serve cannot be invoked from an already-running serve instance")
+		return
+	}
+
+	cmd, ok := commands[action]
+	if !ok {
+		fmt.Printf("Unknown command %q\n", action)
+		return
+	}
+
+	cmd.run(db, args)
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunCommandRejectsRecursiveServe(t *testing.T) {
+	db := newTestDB()
+
+	out := run(t, func(db Backend, args []string) { runCommand(db, "serve", args) }, db)
+
+	if !strings.Contains(out, "cannot be invoked") {
+		t.Errorf("runCommand(serve) = %q, want it to refuse rather than re-enter repl()", out)
+	}
+}
+
+func TestRunCommandStillRunsOrdinaryCommands(t *testing.T) {
+	db := newTestDB()
+
+	run(t, func(db Backend, args []string) { runCommand(db, "add", args) }, db, "fruit")
+
+	if _, err := db.AllKeys("fruit"); err != nil {
+		t.Errorf("AllKeys(fruit) after runCommand(add, fruit) errored: %s, want the bucket to exist", err)
+	}
+}
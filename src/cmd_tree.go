@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const treeUsage = `	tree [path]                      List buckets nested under a "/"-
+	                                 separated path (every bucket, if
+	                                 omitted), indented by depth.`
+
+// tree [path]
+//
+// Buckets named "users/alice" aren't a real sub-bucket of "users" -- they're
+// just two flat bucket names that happen to share a "/" convention -- so
+// this is string matching over AllBuckets, not a real tree walk.
+func tree(db Backend, args []string) {
+	var prefix string
+
+	switch len(args) {
+	case 0:
+	case 1:
+		prefix = args[0]
+	default:
+		usage(treeUsage)
+		return
+	}
+
+	all, err := db.AllBuckets()
+	if err != nil {
+		fmt.Printf("Could not retrieve bucket list: %s\n", err)
+		return
+	}
+
+	for _, bucket := range bucketsUnder(withoutMetaBuckets(all), prefix) {
+		depth := strings.Count(bucket, "/")
+		fmt.Printf("%s%s\n", strings.Repeat("  ", depth), bucket)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTree(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("users")
+	db.CreateBucket("users/alice")
+	db.CreateBucket("users/bob")
+	db.CreateBucket("groups")
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantNot []string
+	}{
+		{"no prefix lists everything", nil, []string{"users", "users/alice", "users/bob", "groups"}, nil},
+		{"prefix filters to the subtree", []string{"users"}, []string{"users", "users/alice", "users/bob"}, []string{"groups"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := run(t, tree, db, tt.args...)
+			for _, w := range tt.want {
+				if !strings.Contains(out, w) {
+					t.Errorf("tree(%v) = %q, want it to contain %q", tt.args, out, w)
+				}
+			}
+			for _, w := range tt.wantNot {
+				if strings.Contains(out, w) {
+					t.Errorf("tree(%v) = %q, should not contain %q", tt.args, out, w)
+				}
+			}
+		})
+	}
+}
+
+func TestTreeHidesMetaBuckets(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	db.CreateBucket(metaBucket("fruit"))
+
+	out := run(t, tree, db)
+
+	if strings.Contains(out, "__meta") {
+		t.Errorf("tree() = %q, should not list TTL __meta buckets", out)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const valUsage = `	val <bucketname>                 Get a list of values in a bucket.
+	val <bucketname> <string>        Get a list of values in a bucket, which
+	                                 contain the string.`
+
+// val <bucketname>            Return all values in the bucket.
+// val <bucketname> <string>   Return all values in the bucket, which contain the string.
+func val(db Backend, args []string) {
+	switch len(args) {
+	case 1:
+		keys, err := db.AllKeys(args[0])
+		if err != nil {
+			fmt.Printf("Could not get values from bucket %s: %s\n", args[0], err)
+		}
+
+		for _, key := range keys {
+			val, expired := readWithMeta(db, args[0], key)
+			if !expired {
+				fmt.Println(string(val))
+			}
+		}
+	case 2:
+		keys, err := db.AllKeys(args[0])
+		if err != nil {
+			fmt.Printf("Could not find values matching %s in bucket %s: %s\n", args[1], args[0], err)
+		}
+
+		for _, key := range keys {
+			val, expired := readWithMeta(db, args[0], key)
+			if !expired && strings.Contains(string(val), args[1]) {
+				fmt.Println(string(val))
+			}
+		}
+	default:
+		usage(valUsage)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVal(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	db.Write("fruit", "apple", []byte("red"))
+	db.Write("fruit", "banana", []byte("yellow"))
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantNot string
+	}{
+		{"all values", []string{"fruit"}, "red", ""},
+		{"values matching string", []string{"fruit", "yell"}, "yellow", "red"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := run(t, val, db, tt.args...)
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("val(%v) = %q, want substring %q", tt.args, out, tt.want)
+			}
+			if tt.wantNot != "" && strings.Contains(out, tt.wantNot) {
+				t.Errorf("val(%v) = %q, should not contain %q", tt.args, out, tt.wantNot)
+			}
+		})
+	}
+}
+
+func TestValSkipsExpired(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	writeWithTTL(db, "fruit", "apple", []byte("red"), -1)
+
+	out := run(t, val, db, "fruit")
+
+	if strings.Contains(out, "red") {
+		t.Errorf("val(fruit) = %q, should skip an expired key's value", out)
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+)
+
+const vbkUsage = `	vbk <bucketname> <string>        Get a list of values where the key
+	                                 contains the string.`
+
+// vbk <bucketname> <string>   Return all values in the bucket whose key contains the string.
+func vbk(db Backend, args []string) {
+	switch len(args) {
+	case 2:
+		keys, err := db.FindKeys(args[0], args[1])
+		if err != nil {
+			fmt.Printf("Could not find values for keys matching %s in bucket %s: %s\n", args[1], args[0], err)
+			return
+		}
+
+		for _, key := range keys {
+			val, expired := readWithMeta(db, args[0], key)
+			if !expired {
+				fmt.Println(string(val))
+			}
+		}
+	default:
+		usage(vbkUsage)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVbk(t *testing.T) {
+	db := newTestDB()
+	db.CreateBucket("fruit")
+	db.Write("fruit", "apple", []byte("red"))
+	db.Write("fruit", "banana", []byte("yellow"))
+
+	out := run(t, vbk, db, "fruit", "app")
+
+	if !strings.Contains(out, "red") {
+		t.Errorf("vbk(fruit, app) = %q, want substring %q", out, "red")
+	}
+	if strings.Contains(out, "yellow") {
+		t.Errorf("vbk(fruit, app) = %q, should not include banana's value", out)
+	}
+}
+
+func TestVbkBadArgs(t *testing.T) {
+	db := newTestDB()
+	out := run(t, vbk, db, "fruit")
+
+	if !strings.Contains(out, vbkUsage) {
+		t.Errorf("vbk with wrong arg count = %q, want usage text", out)
+	}
+}
@@ -0,0 +1,15 @@
+package main
+
+import "testing"
+
+// run is the shared test helper: it runs a command's handler against a
+// fresh memBackend and returns whatever it printed to stdout, the same way
+// a real invocation's output would read on the terminal.
+func run(t *testing.T, fn func(db Backend, args []string), db Backend, args ...string) string {
+	t.Helper()
+	return string(captureOutput(func() { fn(db, args) }))
+}
+
+func newTestDB() Backend {
+	return newMemBackend()
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/asggo/store"
+)
+
+// command is one kv subcommand. It mirrors the shape cmd/bolt uses: a short
+// usage blurb for the help text and a Run func that receives the already
+// open database plus whatever arguments followed the action on the command
+// line.
+type command struct {
+	usage string
+	run   func(db Backend, args []string)
+}
+
+// commands is the subcommand registry. Keeping every action's usage and
+// handler next to each other here, with the implementation living in its
+// own cmd_*.go file, is what replaces the old single giant switch.
+//
+// This is built up in init() rather than a var initializer: serve's handler
+// looks commands back up (to run whatever action a client sends), and a
+// var initializer that reaches back into itself that way is a compile-time
+// initialization cycle rather than the ordinary runtime lookup it actually is.
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"add":    {usage: addUsage, run: add},
+		"get":    {usage: getUsage, run: get},
+		"val":    {usage: valUsage, run: val},
+		"del":    {usage: delUsage, run: del},
+		"vbk":    {usage: vbkUsage, run: vbk},
+		"kbv":    {usage: kbvUsage, run: kbv},
+		"find":   {usage: findUsage, run: find},
+		"backup": {usage: backupUsage, run: backup},
+		"export": {usage: exportUsage, run: export},
+		"import": {usage: importUsage, run: doImport},
+		"dump":   {usage: dumpUsage, run: dump},
+		"serve":  {usage: serveUsage, run: serve},
+		"expire": {usage: expireUsage, run: expire},
+		"batch":  {usage: batchUsage, run: batch},
+		"tree":   {usage: treeUsage, run: tree},
+	}
+}
+
+// commandOrder is the order actions are listed in help.
+var commandOrder = []string{
+	"add", "get", "val", "vbk", "kbv", "del", "find", "backup",
+	"export", "import", "dump", "serve", "expire", "batch", "tree",
+}
+
+func help() {
+	u := `Usage:
+	kv filename action [arguments]
+
+Actions:
+`
+	fmt.Println(u)
+
+	for _, name := range commandOrder {
+		fmt.Println(commands[name].usage)
+	}
+
+	fmt.Println(clientUsage)
+	os.Exit(1)
+}
+
+// usage prints one command's usage blurb without exiting. It's what a
+// command's handler calls on malformed arguments, so a bad request over the
+// serve protocol or REPL can't take the whole process down the way help()
+// (which calls os.Exit) would.
+func usage(u string) {
+	fmt.Println(u)
+}
+
+func printlist(items []string) {
+	for _, item := range items {
+		fmt.Println(item)
+	}
+}
+
+func main() {
+	if len(os.Args) < 3 {
+		help()
+	}
+
+	// `kv <addr> client <action> [arguments]` talks to a running `kv serve`
+	// instance over the network instead of opening a database file, so it
+	// has to be handled before we try to open anything.
+	if os.Args[2] == "client" {
+		client(os.Args[1], os.Args[3:])
+		return
+	}
+
+	// Open our database, either a real bbolt file or, for ":memory:", the
+	// dependency-free in-memory Backend (see backend.go).
+	dbfile := os.Args[1]
+
+	var db Backend
+	if dbfile == ":memory:" {
+		db = newMemBackend()
+	} else {
+		s, err := store.NewStore(dbfile)
+		if err != nil {
+			fmt.Println("Could not open database file:", err)
+		}
+		db = s
+	}
+	defer db.Close()
+
+	action := os.Args[2]
+
+	cmd, ok := commands[action]
+	if !ok {
+		help()
+	}
+
+	cmd.run(db, os.Args[3:])
+}
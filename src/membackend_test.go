@@ -0,0 +1,50 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMemBackendListsAreSorted(t *testing.T) {
+	db := newMemBackend()
+	for _, bucket := range []string{"zebra", "apple", "mango"} {
+		db.CreateBucket(bucket)
+	}
+	for _, key := range []string{"zkey", "akey", "mkey"} {
+		db.Write("apple", key, []byte("v"))
+	}
+
+	buckets, err := db.AllBuckets()
+	if err != nil {
+		t.Fatalf("AllBuckets: %s", err)
+	}
+	wantBuckets := []string{"apple", "mango", "zebra"}
+	if !reflect.DeepEqual(buckets, wantBuckets) {
+		t.Errorf("AllBuckets() = %v, want %v (sorted, matching bbolt's B+tree order)", buckets, wantBuckets)
+	}
+
+	keys, err := db.AllKeys("apple")
+	if err != nil {
+		t.Fatalf("AllKeys: %s", err)
+	}
+	wantKeys := []string{"akey", "mkey", "zkey"}
+	if !reflect.DeepEqual(keys, wantKeys) {
+		t.Errorf("AllKeys(apple) = %v, want %v (sorted)", keys, wantKeys)
+	}
+}
+
+func TestMemBackendFindListsAreSorted(t *testing.T) {
+	db := newMemBackend()
+	for _, bucket := range []string{"zfruit", "afruit", "mfruit"} {
+		db.CreateBucket(bucket)
+	}
+
+	buckets, err := db.FindBuckets("fruit")
+	if err != nil {
+		t.Fatalf("FindBuckets: %s", err)
+	}
+	want := []string{"afruit", "mfruit", "zfruit"}
+	if !reflect.DeepEqual(buckets, want) {
+		t.Errorf("FindBuckets(fruit) = %v, want %v (sorted)", buckets, want)
+	}
+}
@@ -0,0 +1,13 @@
+package main
+
+import "strings"
+
+// dialNetwork picks the net.Dial/net.Listen network for addr: a path
+// (anything containing a "/") is a Unix socket, anything else is treated as
+// a TCP host:port.
+func dialNetwork(addr string) string {
+	if strings.Contains(addr, "/") {
+		return "unix"
+	}
+	return "tcp"
+}
@@ -0,0 +1,29 @@
+package main
+
+import "strings"
+
+// store.Store has no notion of nested buckets -- it is flat, and the
+// (external, github.com/asggo/store) package has no CreateBucketIfNotExists
+// equivalent for sub-buckets we could recurse into. What we can do without
+// touching that package is treat "/"-separated bucket names as a path
+// convention: "users/alice" is just a bucket name as far as Store is
+// concerned, so add/get/del/etc. already accept it with no changes. tree
+// (cmd_tree.go) is the one piece of real logic this adds: listing which
+// flat bucket names live under a given path prefix.
+
+// bucketsUnder returns every bucket in all whose name is prefix itself or
+// nested under it ("prefix/...."). An empty prefix matches every bucket.
+func bucketsUnder(all []string, prefix string) []string {
+	if prefix == "" {
+		return all
+	}
+
+	var matches []string
+	for _, b := range all {
+		if b == prefix || strings.HasPrefix(b, prefix+"/") {
+			matches = append(matches, b)
+		}
+	}
+
+	return matches
+}
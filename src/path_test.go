@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestBucketsUnder(t *testing.T) {
+	all := []string{"users", "users/alice", "users/bob", "groups"}
+
+	tests := []struct {
+		name   string
+		prefix string
+		want   int
+	}{
+		{"empty prefix matches everything", "", 4},
+		{"prefix matches itself and nested buckets", "users", 3},
+		{"prefix with no matches", "orgs", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bucketsUnder(all, tt.prefix)
+			if len(got) != tt.want {
+				t.Errorf("bucketsUnder(%v, %q) = %v, want %d matches", all, tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The serve/client transport uses a small RESP-like line protocol so other
+// programs can pipeline requests without linking against this binary:
+//
+//	*<n>\r\n$<len>\r\n<arg>\r\n ... (n times)
+//
+// Replies are a single bulk string carrying whatever the command would have
+// printed to stdout:
+//
+//	$<len>\r\n<data>\r\n
+
+// maxArrayLen and maxBulkLen bound the lengths a peer can claim in an array
+// header or bulk string. Without a cap a negative length underflows the
+// "+2 for \r\n" math into a negative make() size and panics, and a huge
+// positive length tries to allocate gigabytes -- either way one malicious
+// frame takes down every connection this process is serving, not just its
+// own. 64 MiB is far above any value or batch of args kv actually sends.
+const (
+	maxArrayLen = 1 << 16
+	maxBulkLen  = 64 << 20
+)
+
+// writeCommand frames args as a RESP-like array of bulk strings and writes
+// it to w.
+func writeCommand(w *bufio.Writer, args []string) error {
+	if _, err := fmt.Fprintf(w, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+
+	for _, arg := range args {
+		if err := writeBulkString(w, []byte(arg)); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// readCommand reads one framed command off r.
+func readCommand(r *bufio.Reader) ([]string, error) {
+	n, err := readArrayHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, n)
+	for i := 0; i < n; i++ {
+		arg, err := readBulkString(r)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = string(arg)
+	}
+
+	return args, nil
+}
+
+// writeReply frames data as a single bulk string and writes it to w.
+func writeReply(w *bufio.Writer, data []byte) error {
+	if err := writeBulkString(w, data); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// readReply reads one framed reply off r.
+func readReply(r *bufio.Reader) ([]byte, error) {
+	return readBulkString(r)
+}
+
+func writeBulkString(w *bufio.Writer, data []byte) error {
+	if _, err := fmt.Fprintf(w, "$%d\r\n", len(data)); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	_, err := w.WriteString("\r\n")
+	return err
+}
+
+func readArrayHeader(r *bufio.Reader) (int, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return 0, err
+	}
+
+	if !strings.HasPrefix(line, "*") {
+		return 0, fmt.Errorf("protocol error: expected array header, got %q", line)
+	}
+
+	n, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 || n > maxArrayLen {
+		return 0, fmt.Errorf("protocol error: array length %d out of range", n)
+	}
+
+	return n, nil
+}
+
+func readBulkString(r *bufio.Reader) ([]byte, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(line, "$") {
+		return nil, fmt.Errorf("protocol error: expected bulk string, got %q", line)
+	}
+
+	n, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || n > maxBulkLen {
+		return nil, fmt.Errorf("protocol error: bulk string length %d out of range", n)
+	}
+
+	buf := make([]byte, n+2) // +2 for the trailing \r\n
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+
+	return buf[:n], nil
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
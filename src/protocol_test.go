@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReadCommandRoundTrips(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+
+	if err := writeCommand(w, []string{"get", "fruit", "apple"}); err != nil {
+		t.Fatalf("writeCommand: %s", err)
+	}
+
+	args, err := readCommand(bufio.NewReader(&buf))
+	if err != nil {
+		t.Fatalf("readCommand: %s", err)
+	}
+
+	want := []string{"get", "fruit", "apple"}
+	if len(args) != len(want) {
+		t.Fatalf("readCommand() = %v, want %v", args, want)
+	}
+	for i := range want {
+		if args[i] != want[i] {
+			t.Errorf("args[%d] = %q, want %q", i, args[i], want[i])
+		}
+	}
+}
+
+func TestReadBulkStringRejectsNegativeLength(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("$-3\r\n"))
+
+	if _, err := readBulkString(r); err == nil {
+		t.Error("readBulkString on a negative length did not error, want a protocol error")
+	}
+}
+
+func TestReadBulkStringRejectsOversizedLength(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("$2000000000\r\n"))
+
+	if _, err := readBulkString(r); err == nil {
+		t.Error("readBulkString on an oversized length did not error, want a protocol error")
+	}
+}
+
+func TestReadArrayHeaderRejectsNegativeLength(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("*-1\r\n"))
+
+	if _, err := readArrayHeader(r); err == nil {
+		t.Error("readArrayHeader on a negative length did not error, want a protocol error")
+	}
+}
+
+func TestDispatchSafelyRecoversFromPanic(t *testing.T) {
+	commands["__panic"] = command{
+		usage: "",
+		run:   func(db Backend, args []string) { panic("boom") },
+	}
+	defer delete(commands, "__panic")
+
+	out := dispatchSafely(newTestDB(), []string{"__panic"})
+
+	if !strings.Contains(string(out), "internal error") {
+		t.Errorf("dispatchSafely() = %q, want it to recover and report an internal error", out)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"strconv"
+	"time"
+)
+
+// store.Store has no notion of expiry -- and it lives in a separate module
+// we don't control, so we can't teach it one. Instead we keep a second
+// bucket alongside each TTL'd bucket, "<bucket>__meta", mapping key to the
+// unix-nano timestamp it expires at. The value itself is never touched, so
+// plain (non-TTL) add/get/val/etc. keep working exactly as before.
+
+func metaBucket(bucket string) string {
+	return bucket + "__meta"
+}
+
+// writeWithTTL writes value to bucket/key and records that it expires after
+// ttl.
+func writeWithTTL(db Backend, bucket, key string, value []byte, ttl time.Duration) error {
+	if err := db.CreateBucket(metaBucket(bucket)); err != nil {
+		return err
+	}
+
+	if err := db.Write(bucket, key, value); err != nil {
+		return err
+	}
+
+	return setExpiry(db, bucket, key, time.Now().Add(ttl))
+}
+
+// setExpiry records bucket/key's expiration time without touching its value.
+func setExpiry(db Backend, bucket, key string, exp time.Time) error {
+	if err := db.CreateBucket(metaBucket(bucket)); err != nil {
+		return err
+	}
+
+	return db.Write(metaBucket(bucket), key, []byte(strconv.FormatInt(exp.UnixNano(), 10)))
+}
+
+// readWithMeta reads bucket/key, transparently treating it as absent if its
+// recorded expiry has passed. An expired entry is deleted from both the
+// value and meta buckets before readWithMeta returns.
+func readWithMeta(db Backend, bucket, key string) (value []byte, expired bool) {
+	if expiredAt(db, bucket, key) {
+		db.Delete(bucket, key)
+		db.Delete(metaBucket(bucket), key)
+		return nil, true
+	}
+
+	return db.Read(bucket, key), false
+}
+
+// expiredAt reports whether bucket/key has a recorded expiry that has
+// already passed. A key with no recorded expiry never expires.
+func expiredAt(db Backend, bucket, key string) bool {
+	raw := db.Read(metaBucket(bucket), key)
+	if raw == nil {
+		return false
+	}
+
+	exp, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return time.Now().UnixNano() > exp
+}
+
+// reap deletes every expired key in bucket and returns how many it removed.
+func reap(db Backend, bucket string) (int, error) {
+	keys, err := db.AllKeys(metaBucket(bucket))
+	if err != nil {
+		// No meta bucket means nothing in this bucket carries a TTL.
+		return 0, nil
+	}
+
+	var n int
+	for _, key := range keys {
+		if expiredAt(db, bucket, key) {
+			db.Delete(bucket, key)
+			db.Delete(metaBucket(bucket), key)
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// reapAll runs reap over every non-meta bucket in the database.
+func reapAll(db Backend) (int, error) {
+	buckets, err := db.AllBuckets()
+	if err != nil {
+		return 0, err
+	}
+
+	var total int
+	for _, bucket := range buckets {
+		if isMetaBucket(bucket) {
+			continue
+		}
+
+		n, err := reap(db, bucket)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+
+	return total, nil
+}
+
+func isMetaBucket(bucket string) bool {
+	const suffix = "__meta"
+	return len(bucket) > len(suffix) && bucket[len(bucket)-len(suffix):] == suffix
+}
+
+// withoutMetaBuckets drops the "<bucket>__meta" entries writeWithTTL/setExpiry
+// create from a bucket listing, so TTL bookkeeping never shows up as a
+// bucket a caller might add/get/del against directly.
+func withoutMetaBuckets(buckets []string) []string {
+	var visible []string
+	for _, bucket := range buckets {
+		if !isMetaBucket(bucket) {
+			visible = append(visible, bucket)
+		}
+	}
+	return visible
+}